@@ -0,0 +1,30 @@
+package gohistogram
+
+// Copyright (c) 2013 VividCortex, Inc. All rights reserved.
+// Please see the LICENSE file for applicable license terms.
+
+import "errors"
+
+// ErrNilHistogram is returned by Merge when asked to merge a nil
+// histogram into the receiver.
+var ErrNilHistogram = errors.New("gohistogram: cannot merge a nil histogram")
+
+// A Bin is a histogram bucket that holds a representative Value along
+// with the weight (Count) of the samples that have been folded into it.
+type Bin struct {
+	Value float64
+	Count float64
+}
+
+// A Histogram is any of the histogram implementations in this package.
+// It approximates a distribution of float64 values using a bounded
+// number of Bins.
+type Histogram interface {
+	Add(value float64)
+	Quantile(float64) float64
+	String() string
+	CDF(value float64) float64
+	Count() float64
+	Mean() float64
+	Variance() float64
+}
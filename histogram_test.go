@@ -0,0 +1,73 @@
+package gohistogram
+
+import "testing"
+
+func TestWeightedHistogramMarshalRoundTrip(t *testing.T) {
+	h := NewWeightedHistogram(20, 0.2)
+	for i := 1; i <= 100; i++ {
+		h.Add(float64(i))
+	}
+
+	wantQuantile := h.Quantile(0.5)
+
+	data, err := h.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	jsonRoundTrip := &WeightedHistogram{}
+	if err := jsonRoundTrip.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if got := jsonRoundTrip.Quantile(0.5); got != wantQuantile {
+		t.Errorf("JSON round trip Quantile(0.5) = %v, want %v", got, wantQuantile)
+	}
+
+	bin, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	binaryRoundTrip := &WeightedHistogram{}
+	if err := binaryRoundTrip.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if got := binaryRoundTrip.Quantile(0.5); got != wantQuantile {
+		t.Errorf("binary round trip Quantile(0.5) = %v, want %v", got, wantQuantile)
+	}
+}
+
+func TestNumericHistogramMarshalRoundTrip(t *testing.T) {
+	h := NewHistogram(20)
+	for i := 1; i <= 100; i++ {
+		h.Add(float64(i))
+	}
+
+	wantQuantile := h.Quantile(0.5)
+
+	data, err := h.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	jsonRoundTrip := &NumericHistogram{}
+	if err := jsonRoundTrip.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if got := jsonRoundTrip.Quantile(0.5); got != wantQuantile {
+		t.Errorf("JSON round trip Quantile(0.5) = %v, want %v", got, wantQuantile)
+	}
+
+	bin, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	binaryRoundTrip := &NumericHistogram{}
+	if err := binaryRoundTrip.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if got := binaryRoundTrip.Quantile(0.5); got != wantQuantile {
+		t.Errorf("binary round trip Quantile(0.5) = %v, want %v", got, wantQuantile)
+	}
+}
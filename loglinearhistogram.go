@@ -0,0 +1,222 @@
+package gohistogram
+
+import (
+	"fmt"
+	"math"
+)
+
+// Exponents in the range [llExponentMin, llExponentMax] are tracked,
+// each split into 90 sub-buckets covering the mantissa digit pairs
+// 10..99 (i.e. m in [1.0, 10.0) truncated to one decimal digit). This
+// bounds the relative error of any bucket's mid-value to roughly 5%.
+const (
+	llExponentMin     = -128
+	llExponentMax     = 127
+	llExponentRange   = llExponentMax - llExponentMin + 1
+	llMantissaBuckets = 90
+)
+
+// A LogLinearHistogram is a Histogram implementation that uses a fixed
+// set of log-linear buckets instead of the current types' adaptive
+// binning. Unlike WeightedHistogram and NumericHistogram, it merges
+// losslessly, has O(1) insert, and gives a provable ~5% relative error
+// bound on any reported value whose exponent lies within
+// [llExponentMin, llExponentMax], at the cost of not adapting its bucket
+// boundaries to the data it sees. Values outside that exponent range are
+// clamped into the lowest or highest decade, so the bound does not hold
+// for them.
+type LogLinearHistogram struct {
+	neg   [llExponentRange][llMantissaBuckets]uint64
+	pos   [llExponentRange][llMantissaBuckets]uint64
+	zero  uint64
+	total uint64
+}
+
+// NewLogLinearHistogram returns a new, empty LogLinearHistogram.
+func NewLogLinearHistogram() *LogLinearHistogram {
+	return &LogLinearHistogram{}
+}
+
+// Add records a new value in the histogram. A value v is decomposed as
+// v = m * 10^e with m in [1.0, 10.0), and increments the counter for the
+// sub-bucket that m falls into.
+func (h *LogLinearHistogram) Add(v float64) {
+	h.total++
+
+	if v == 0 {
+		h.zero++
+		return
+	}
+
+	buckets := &h.pos
+	if v < 0 {
+		buckets = &h.neg
+		v = -v
+	}
+
+	e := int(math.Floor(math.Log10(v)))
+	m := v / math.Pow10(e)
+
+	// Floating point error can push m just outside [1.0, 10.0); correct
+	// for it rather than letting the bucket index go out of range.
+	if m >= 10 {
+		m /= 10
+		e++
+	} else if m < 1 {
+		m *= 10
+		e--
+	}
+
+	if e < llExponentMin {
+		e = llExponentMin
+	} else if e > llExponentMax {
+		e = llExponentMax
+	}
+
+	bucket := int((m - 1) * 10)
+	if bucket >= llMantissaBuckets {
+		bucket = llMantissaBuckets - 1
+	} else if bucket < 0 {
+		bucket = 0
+	}
+
+	buckets[e-llExponentMin][bucket]++
+}
+
+// Merge folds other's bucket counts into h by summing the parallel
+// counter arrays element-wise. Because both histograms share the exact
+// same fixed bucket layout, this is lossless: it never requires
+// re-binning or approximating a merged bucket's representative value,
+// unlike Merge on WeightedHistogram or NumericHistogram.
+func (h *LogLinearHistogram) Merge(other *LogLinearHistogram) error {
+	if other == nil {
+		return ErrNilHistogram
+	}
+
+	for e := 0; e < llExponentRange; e++ {
+		for m := 0; m < llMantissaBuckets; m++ {
+			h.neg[e][m] += other.neg[e][m]
+			h.pos[e][m] += other.pos[e][m]
+		}
+	}
+	h.zero += other.zero
+	h.total += other.total
+
+	return nil
+}
+
+// bucketMidValue returns the representative value of the bucket at
+// exponent index e (where the true exponent is e+llExponentMin) and
+// mantissa bucket m.
+func bucketMidValue(e, m int) float64 {
+	mantissa := 1.0 + float64(m)/10 + 0.05
+	return mantissa * math.Pow10(e+llExponentMin)
+}
+
+// forEachAscending calls f once per non-empty bucket, in ascending value
+// order: most negative first, then the zero bucket, then ascending
+// positive values.
+func (h *LogLinearHistogram) forEachAscending(f func(value float64, count uint64)) {
+	for e := llExponentRange - 1; e >= 0; e-- {
+		for m := llMantissaBuckets - 1; m >= 0; m-- {
+			if c := h.neg[e][m]; c > 0 {
+				f(-bucketMidValue(e, m), c)
+			}
+		}
+	}
+
+	if h.zero > 0 {
+		f(0, h.zero)
+	}
+
+	for e := 0; e < llExponentRange; e++ {
+		for m := 0; m < llMantissaBuckets; m++ {
+			if c := h.pos[e][m]; c > 0 {
+				f(bucketMidValue(e, m), c)
+			}
+		}
+	}
+}
+
+// Quantile returns the approximate value at quantile q (0..1).
+func (h *LogLinearHistogram) Quantile(q float64) float64 {
+	target := q * float64(h.total)
+	result := -1.0
+
+	done := false
+	h.forEachAscending(func(value float64, count uint64) {
+		if done {
+			return
+		}
+
+		target -= float64(count)
+		if target <= 0 {
+			result = value
+			done = true
+		}
+	})
+
+	return result
+}
+
+// CDF returns the value of the cumulative distribution function at x.
+func (h *LogLinearHistogram) CDF(x float64) float64 {
+	count := 0.0
+	h.forEachAscending(func(value float64, c uint64) {
+		if value <= x {
+			count += float64(c)
+		}
+	})
+
+	return count / float64(h.total)
+}
+
+// Mean returns the sample mean of the distribution.
+func (h *LogLinearHistogram) Mean() float64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	h.forEachAscending(func(value float64, c uint64) {
+		sum += value * float64(c)
+	})
+
+	return sum / float64(h.total)
+}
+
+// Variance returns the variance of the distribution.
+func (h *LogLinearHistogram) Variance() float64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	mean := h.Mean()
+	sum := 0.0
+	h.forEachAscending(func(value float64, c uint64) {
+		sum += float64(c) * (value - mean) * (value - mean)
+	})
+
+	return sum / float64(h.total)
+}
+
+// Count returns the total number of samples recorded in the histogram.
+func (h *LogLinearHistogram) Count() float64 {
+	return float64(h.total)
+}
+
+// String returns a string reprentation of the histogram,
+// which is useful for printing to a terminal.
+func (h *LogLinearHistogram) String() (str string) {
+	str += fmt.Sprintln("Total:", h.total)
+
+	h.forEachAscending(func(value float64, c uint64) {
+		var bar string
+		for i := 0; i < int(float64(c)/float64(h.total)*200); i++ {
+			bar += "."
+		}
+		str += fmt.Sprintln(value, "\t", bar)
+	})
+
+	return
+}
@@ -0,0 +1,102 @@
+package gohistogram
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogLinearHistogramRelativeErrorBound(t *testing.T) {
+	h := NewLogLinearHistogram()
+	for i := 0; i < 10; i++ {
+		h.Add(100)
+	}
+
+	got := h.Quantile(0.5)
+	relErr := math.Abs(got-100) / 100
+	if relErr > 0.051 {
+		t.Errorf("Quantile(0.5) = %v, relative error %v exceeds the ~5%% bound", got, relErr)
+	}
+	if got := h.Count(); got != 10 {
+		t.Errorf("Count() = %v, want 10", got)
+	}
+}
+
+func TestLogLinearHistogramZeroBucket(t *testing.T) {
+	h := NewLogLinearHistogram()
+	h.Add(0)
+	h.Add(0)
+	h.Add(0)
+
+	if got := h.Mean(); got != 0 {
+		t.Errorf("Mean() = %v, want 0", got)
+	}
+	if got := h.CDF(0); got != 1 {
+		t.Errorf("CDF(0) = %v, want 1", got)
+	}
+	if got := h.Quantile(0.5); got != 0 {
+		t.Errorf("Quantile(0.5) = %v, want 0", got)
+	}
+}
+
+func TestLogLinearHistogramNegativeValuesSortBeforePositive(t *testing.T) {
+	h := NewLogLinearHistogram()
+	h.Add(-100)
+	h.Add(100)
+
+	if got := h.Quantile(0.25); got >= 0 {
+		t.Errorf("Quantile(0.25) = %v, want a negative value", got)
+	}
+	if got := h.Quantile(0.75); got <= 0 {
+		t.Errorf("Quantile(0.75) = %v, want a positive value", got)
+	}
+	if got := h.CDF(0); got != 0.5 {
+		t.Errorf("CDF(0) = %v, want 0.5", got)
+	}
+}
+
+func TestLogLinearHistogramClampsExtremeExponents(t *testing.T) {
+	h := NewLogLinearHistogram()
+
+	// Exercise values whose exponent falls far outside
+	// [llExponentMin, llExponentMax] in both directions; Add must clamp
+	// into range rather than index out of bounds.
+	h.Add(math.MaxFloat64)
+	h.Add(-math.MaxFloat64)
+	h.Add(1e-300)
+	h.Add(-1e-300)
+
+	if got := h.Count(); got != 4 {
+		t.Errorf("Count() = %v, want 4", got)
+	}
+	if got := h.Quantile(1); math.IsInf(got, 0) || math.IsNaN(got) {
+		t.Errorf("Quantile(1) = %v, want a finite clamped value", got)
+	}
+}
+
+func TestLogLinearHistogramMergeSumsCounts(t *testing.T) {
+	a := NewLogLinearHistogram()
+	a.Add(100)
+	a.Add(-50)
+
+	b := NewLogLinearHistogram()
+	b.Add(100)
+	b.Add(0)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if got := a.Count(); got != 4 {
+		t.Errorf("Count() after Merge = %v, want 4", got)
+	}
+	if got := a.CDF(0); got != 0.5 {
+		t.Errorf("CDF(0) after Merge = %v, want 0.5 (2 of 4 samples <= 0)", got)
+	}
+}
+
+func TestLogLinearHistogramMergeNil(t *testing.T) {
+	h := NewLogLinearHistogram()
+	if err := h.Merge(nil); err != ErrNilHistogram {
+		t.Errorf("Merge(nil) error = %v, want ErrNilHistogram", err)
+	}
+}
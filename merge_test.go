@@ -0,0 +1,98 @@
+package gohistogram
+
+import "testing"
+
+func TestWeightedHistogramMergeSortedSum(t *testing.T) {
+	h1 := &WeightedHistogram{
+		Bins:    []Bin{{Value: 1, Count: 1}, {Value: 3, Count: 1}},
+		Maxbins: 20,
+		Total:   2,
+		Alpha:   0.2,
+	}
+	h2 := &WeightedHistogram{
+		Bins:  []Bin{{Value: 2, Count: 1}, {Value: 3, Count: 2}},
+		Total: 3,
+	}
+
+	if err := h1.Merge(h2); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	want := []Bin{{Value: 1, Count: 1}, {Value: 2, Count: 1}, {Value: 3, Count: 3}}
+	if len(h1.Bins) != len(want) {
+		t.Fatalf("Bins = %v, want %v", h1.Bins, want)
+	}
+	for i, bin := range want {
+		if h1.Bins[i] != bin {
+			t.Errorf("Bins[%d] = %v, want %v", i, h1.Bins[i], bin)
+		}
+	}
+	if h1.Total != 5 {
+		t.Errorf("Total = %v, want 5", h1.Total)
+	}
+}
+
+func TestWeightedHistogramMergeNil(t *testing.T) {
+	h := NewWeightedHistogram(20, 0.2)
+	if err := h.Merge(nil); err != ErrNilHistogram {
+		t.Errorf("Merge(nil) error = %v, want ErrNilHistogram", err)
+	}
+}
+
+func TestNumericHistogramMergeSortedSum(t *testing.T) {
+	h1 := NewHistogram(20)
+	h1.Add(1)
+	h1.Add(2)
+	h1.Add(3)
+
+	h2 := NewHistogram(20)
+	h2.Add(2)
+	h2.Add(4)
+
+	if err := h1.Merge(h2); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	want := []Bin{{Value: 1, Count: 1}, {Value: 2, Count: 2}, {Value: 3, Count: 1}, {Value: 4, Count: 1}}
+	if len(h1.bins) != len(want) {
+		t.Fatalf("bins = %v, want %v", h1.bins, want)
+	}
+	for i, bin := range want {
+		if h1.bins[i] != bin {
+			t.Errorf("bins[%d] = %v, want %v", i, h1.bins[i], bin)
+		}
+	}
+	if h1.Count() != 5 {
+		t.Errorf("Count() = %v, want 5", h1.Count())
+	}
+}
+
+func TestNumericHistogramMergeCarriesMinMaxSum(t *testing.T) {
+	h1 := NewHistogram(20)
+	h1.Add(5)
+
+	h2 := NewHistogram(20)
+	h2.Add(1)
+	h2.Add(9)
+
+	if err := h1.Merge(h2); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if h1.Min() != 1 {
+		t.Errorf("Min() = %v, want 1", h1.Min())
+	}
+	if h1.Max() != 9 {
+		t.Errorf("Max() = %v, want 9", h1.Max())
+	}
+	if h1.Sum() != 15 {
+		t.Errorf("Sum() = %v, want 15", h1.Sum())
+	}
+}
+
+func TestNumericHistogramMergeNil(t *testing.T) {
+	h := NewHistogram(20)
+	if err := h.Merge(nil); err != ErrNilHistogram {
+		t.Errorf("Merge(nil) error = %v, want ErrNilHistogram", err)
+	}
+}
@@ -0,0 +1,264 @@
+package gohistogram
+
+// Copyright (c) 2013 VividCortex, Inc. All rights reserved.
+// Please see the LICENSE file for applicable license terms.
+
+import (
+	"fmt"
+	"math"
+)
+
+// A NumericHistogram is a Histogram implementation that does not decay
+// or weight its values over time. Every sample folded into a Bin counts
+// equally, which makes it suitable for computing quantiles over the
+// lifetime of a data set rather than a recency-weighted approximation.
+type NumericHistogram struct {
+	bins    []Bin
+	maxbins int
+	total   uint64
+	min     float64
+	max     float64
+	sum     float64
+}
+
+// NewHistogram returns a new NumericHistogram with a maximum of n bins.
+//
+// There is no "optimal" bin count, but somewhere between 20 and 80 bins
+// should be sufficient.
+func NewHistogram(n int) *NumericHistogram {
+	return &NumericHistogram{
+		bins:    make([]Bin, 0),
+		maxbins: n,
+		total:   0,
+		min:     math.Inf(1),
+		max:     math.Inf(-1),
+	}
+}
+
+// Add records a new value in the histogram.
+func (h *NumericHistogram) Add(n float64) {
+	h.RecordValues(n, 1)
+}
+
+// RecordValues folds count occurrences of value n into the histogram in
+// a single insert and a single trim(), rather than the count inserts and
+// trims that calling Add in a loop would take.
+func (h *NumericHistogram) RecordValues(n float64, count int64) {
+	defer h.trim()
+
+	if n < h.min {
+		h.min = n
+	}
+	if n > h.max {
+		h.max = n
+	}
+	h.sum += n * float64(count)
+
+	for i := range h.bins {
+		if h.bins[i].Value == n {
+			h.bins[i].Count += float64(count)
+			h.total += uint64(count)
+			return
+		}
+
+		if h.bins[i].Value > n {
+			newbin := Bin{Value: n, Count: float64(count)}
+			head := append(make([]Bin, 0), h.bins[0:i]...)
+
+			head = append(head, newbin)
+			tail := h.bins[i:]
+			h.bins = append(head, tail...)
+
+			h.total += uint64(count)
+			return
+		}
+	}
+
+	h.bins = append(h.bins, Bin{Count: float64(count), Value: n})
+	h.total += uint64(count)
+}
+
+// Min returns the smallest value recorded, or +Inf if none have been
+// recorded yet.
+func (h *NumericHistogram) Min() float64 {
+	return h.min
+}
+
+// Max returns the largest value recorded, or -Inf if none have been
+// recorded yet.
+func (h *NumericHistogram) Max() float64 {
+	return h.max
+}
+
+// Sum returns the sum of every value recorded, weighted by how many
+// times it was folded in via RecordValues.
+func (h *NumericHistogram) Sum() float64 {
+	return h.sum
+}
+
+// ValueAtQuantile returns the approximate value at percentile p (0..100),
+// e.g. ValueAtQuantile(99) for p99.
+func (h *NumericHistogram) ValueAtQuantile(p float64) float64 {
+	return h.Quantile(p / 100)
+}
+
+// ValueAtPercentile is an alias for ValueAtQuantile.
+func (h *NumericHistogram) ValueAtPercentile(p float64) float64 {
+	return h.ValueAtQuantile(p)
+}
+
+// Merge folds other's bins into h, summing the counts of bins with equal
+// values and inserting new bins in sorted order, then re-runs trim() to
+// respect maxbins. This lets independently maintained histograms (e.g.
+// one per shard or per process) be combined into a single global view
+// without re-inserting raw samples.
+func (h *NumericHistogram) Merge(other *NumericHistogram) error {
+	if other == nil {
+		return ErrNilHistogram
+	}
+
+	for _, bin := range other.bins {
+		h.mergeBin(bin)
+	}
+	h.total += other.total
+	h.sum += other.sum
+	if other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+	h.trim()
+
+	return nil
+}
+
+func (h *NumericHistogram) mergeBin(bin Bin) {
+	for i := range h.bins {
+		if h.bins[i].Value == bin.Value {
+			h.bins[i].Count += bin.Count
+			return
+		}
+
+		if h.bins[i].Value > bin.Value {
+			head := append(make([]Bin, 0), h.bins[0:i]...)
+
+			head = append(head, bin)
+			tail := h.bins[i:]
+			h.bins = append(head, tail...)
+			return
+		}
+	}
+
+	h.bins = append(h.bins, bin)
+}
+
+func (h *NumericHistogram) Quantile(q float64) float64 {
+	count := q * float64(h.total)
+	for i := range h.bins {
+		count -= float64(h.bins[i].Count)
+
+		if count <= 0 {
+			return h.bins[i].Value
+		}
+	}
+
+	return -1
+}
+
+// CDF returns the value of the cumulative distribution function
+// at x
+func (h *NumericHistogram) CDF(x float64) float64 {
+	count := 0.0
+	for i := range h.bins {
+		if h.bins[i].Value <= x {
+			count += float64(h.bins[i].Count)
+		}
+	}
+
+	return count / float64(h.total)
+}
+
+// Mean returns the sample mean of the distribution
+func (h *NumericHistogram) Mean() float64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	sum := 0.0
+
+	for i := range h.bins {
+		sum += h.bins[i].Value * float64(h.bins[i].Count)
+	}
+
+	return sum / float64(h.total)
+}
+
+// Variance returns the variance of the distribution
+func (h *NumericHistogram) Variance() float64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	mean := h.Mean()
+
+	for i := range h.bins {
+		sum += (float64(h.bins[i].Count) * (h.bins[i].Value - mean) * (h.bins[i].Value - mean))
+	}
+
+	return sum / float64(h.total)
+}
+
+func (h *NumericHistogram) Count() float64 {
+	return float64(h.total)
+}
+
+func (h *NumericHistogram) trim() {
+	for len(h.bins) > h.maxbins {
+
+		// Find closest bins in terms of value
+		minDelta := 1e99
+		minDeltaIndex := 0
+		for i := range h.bins {
+			if i == 0 {
+				continue
+			}
+
+			if delta := h.bins[i].Value - h.bins[i-1].Value; delta < minDelta {
+				minDelta = delta
+				minDeltaIndex = i
+			}
+		}
+
+		// We need to merge bins minDeltaIndex-1 and minDeltaIndex
+		totalCount := h.bins[minDeltaIndex-1].Count + h.bins[minDeltaIndex].Count
+		mergedbin := Bin{
+			Value: (h.bins[minDeltaIndex-1].Value*
+				h.bins[minDeltaIndex-1].Count +
+				h.bins[minDeltaIndex].Value*
+					h.bins[minDeltaIndex].Count) /
+				totalCount, // weighted average
+			Count: totalCount, // summed heights
+		}
+		head := append(make([]Bin, 0), h.bins[0:minDeltaIndex-1]...)
+		tail := append([]Bin{mergedbin}, h.bins[minDeltaIndex+1:]...)
+		h.bins = append(head, tail...)
+	}
+}
+
+// String returns a string reprentation of the histogram,
+// which is useful for printing to a terminal.
+func (h *NumericHistogram) String() (str string) {
+	str += fmt.Sprintln("Total:", h.total)
+
+	for i := range h.bins {
+		var bar string
+		for j := 0; j < int(float64(h.bins[i].Count)/float64(h.total)*200); j++ {
+			bar += "."
+		}
+		str += fmt.Sprintln(h.bins[i].Value, "\t", bar)
+	}
+
+	return
+}
@@ -0,0 +1,180 @@
+package gohistogram
+
+import (
+	"math"
+	"sync"
+)
+
+// A SafeWeightedHistogram wraps a WeightedHistogram with a sync.RWMutex so
+// that a single histogram can be shared between goroutines — for example
+// an HTTP handler recording latencies alongside a scraper goroutine
+// reading quantiles — without any external locking.
+type SafeWeightedHistogram struct {
+	mu   sync.RWMutex
+	hist *WeightedHistogram
+}
+
+// NewSafeWeightedHistogram returns a new SafeWeightedHistogram with a
+// maximum of n bins and decay factor alpha. See NewWeightedHistogram for
+// details on the arguments.
+func NewSafeWeightedHistogram(n int, alpha float64) *SafeWeightedHistogram {
+	return &SafeWeightedHistogram{hist: NewWeightedHistogram(n, alpha)}
+}
+
+// Add records a new value in the histogram.
+func (h *SafeWeightedHistogram) Add(n float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hist.Add(n)
+}
+
+// Quantile returns the value at the given quantile (0..1).
+func (h *SafeWeightedHistogram) Quantile(q float64) float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Quantile(q)
+}
+
+// CDF returns the value of the cumulative distribution function at x.
+func (h *SafeWeightedHistogram) CDF(x float64) float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.CDF(x)
+}
+
+// Mean returns the sample mean of the distribution.
+func (h *SafeWeightedHistogram) Mean() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Mean()
+}
+
+// Variance returns the variance of the distribution.
+func (h *SafeWeightedHistogram) Variance() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Variance()
+}
+
+// Count returns the total weight recorded in the histogram.
+func (h *SafeWeightedHistogram) Count() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Count()
+}
+
+// Clear resets the histogram's bins and total in place, discarding all
+// previously recorded values.
+func (h *SafeWeightedHistogram) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hist.Bins = make([]Bin, 0)
+	h.hist.Total = 0
+}
+
+// Snapshot returns a copy of the underlying WeightedHistogram that shares
+// no mutable state with the live histogram, so it is safe to read from
+// (Quantile, CDF, Mean, Variance, Count, String) without holding a lock.
+func (h *SafeWeightedHistogram) Snapshot() *WeightedHistogram {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	bins := make([]Bin, len(h.hist.Bins))
+	copy(bins, h.hist.Bins)
+
+	return &WeightedHistogram{
+		Bins:    bins,
+		Maxbins: h.hist.Maxbins,
+		Total:   h.hist.Total,
+		Alpha:   h.hist.Alpha,
+	}
+}
+
+// A SafeNumericHistogram wraps a NumericHistogram with a sync.RWMutex so
+// that a single histogram can be shared between goroutines without any
+// external locking.
+type SafeNumericHistogram struct {
+	mu   sync.RWMutex
+	hist *NumericHistogram
+}
+
+// NewSafeNumericHistogram returns a new SafeNumericHistogram with a
+// maximum of n bins. See NewHistogram for details on the arguments.
+func NewSafeNumericHistogram(n int) *SafeNumericHistogram {
+	return &SafeNumericHistogram{hist: NewHistogram(n)}
+}
+
+// Add records a new value in the histogram.
+func (h *SafeNumericHistogram) Add(n float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hist.Add(n)
+}
+
+// Quantile returns the value at the given quantile (0..1).
+func (h *SafeNumericHistogram) Quantile(q float64) float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Quantile(q)
+}
+
+// CDF returns the value of the cumulative distribution function at x.
+func (h *SafeNumericHistogram) CDF(x float64) float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.CDF(x)
+}
+
+// Mean returns the sample mean of the distribution.
+func (h *SafeNumericHistogram) Mean() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Mean()
+}
+
+// Variance returns the variance of the distribution.
+func (h *SafeNumericHistogram) Variance() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Variance()
+}
+
+// Count returns the total number of samples recorded in the histogram.
+func (h *SafeNumericHistogram) Count() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hist.Count()
+}
+
+// Clear resets the histogram's bins and total in place, discarding all
+// previously recorded values.
+func (h *SafeNumericHistogram) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hist.bins = make([]Bin, 0)
+	h.hist.total = 0
+	h.hist.min = math.Inf(1)
+	h.hist.max = math.Inf(-1)
+	h.hist.sum = 0
+}
+
+// Snapshot returns a copy of the underlying NumericHistogram that shares
+// no mutable state with the live histogram, so it is safe to read from
+// (Quantile, CDF, Mean, Variance, Count, Min, Max, Sum, String) without
+// holding a lock.
+func (h *SafeNumericHistogram) Snapshot() *NumericHistogram {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	bins := make([]Bin, len(h.hist.bins))
+	copy(bins, h.hist.bins)
+
+	return &NumericHistogram{
+		bins:    bins,
+		maxbins: h.hist.maxbins,
+		total:   h.hist.total,
+		min:     h.hist.min,
+		max:     h.hist.max,
+		sum:     h.hist.sum,
+	}
+}
@@ -0,0 +1,132 @@
+package gohistogram
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSafeWeightedHistogramConcurrentAccess(t *testing.T) {
+	h := NewSafeWeightedHistogram(20, 0.2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n float64) {
+			defer wg.Done()
+			h.Add(n)
+		}(float64(i))
+	}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Quantile(0.5)
+			h.CDF(10)
+			h.Mean()
+			h.Variance()
+			h.Count()
+		}()
+	}
+	wg.Wait()
+
+	// WeightedHistogram decays older counts via EWMA, so the exact
+	// count after concurrent Adds isn't predictable; this test exists
+	// to be run with -race and catch unsynchronized access to Bins.
+	if got := h.Count(); got <= 0 {
+		t.Errorf("Count() = %v, want > 0", got)
+	}
+}
+
+func TestSafeWeightedHistogramClearAndSnapshot(t *testing.T) {
+	h := NewSafeWeightedHistogram(20, 0.2)
+	h.Add(1)
+	h.Add(2)
+
+	snap := h.Snapshot()
+	h.Add(3)
+	if snap.Count() != 2 {
+		t.Errorf("Snapshot() was mutated by a later Add: Count() = %v, want 2", snap.Count())
+	}
+
+	h.Clear()
+	if h.Count() != 0 {
+		t.Errorf("Count() after Clear() = %v, want 0", h.Count())
+	}
+}
+
+func TestSafeNumericHistogramConcurrentAccess(t *testing.T) {
+	h := NewSafeNumericHistogram(20)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n float64) {
+			defer wg.Done()
+			h.Add(n)
+		}(float64(i))
+	}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Quantile(0.5)
+			h.CDF(10)
+			h.Mean()
+			h.Variance()
+			h.Count()
+		}()
+	}
+	wg.Wait()
+
+	if got := h.Count(); got != 50 {
+		t.Errorf("Count() = %v, want 50", got)
+	}
+}
+
+func TestSafeNumericHistogramClearResetsMinMaxSum(t *testing.T) {
+	h := NewSafeNumericHistogram(20)
+	h.Add(5)
+
+	h.Clear()
+	h.Add(100)
+
+	snap := h.Snapshot()
+	if snap.Min() != 100 {
+		t.Errorf("Min() after Clear()+Add(100) = %v, want 100", snap.Min())
+	}
+	if snap.Max() != 100 {
+		t.Errorf("Max() after Clear()+Add(100) = %v, want 100", snap.Max())
+	}
+	if snap.Sum() != 100 {
+		t.Errorf("Sum() after Clear()+Add(100) = %v, want 100", snap.Sum())
+	}
+}
+
+func TestSafeNumericHistogramSnapshotIsIndependent(t *testing.T) {
+	h := NewSafeNumericHistogram(20)
+	h.Add(1)
+	h.Add(9)
+
+	snap := h.Snapshot()
+	h.Add(100)
+
+	if snap.Count() != 2 {
+		t.Errorf("Snapshot() Count() = %v, want 2 (unaffected by later Add)", snap.Count())
+	}
+	if snap.Max() != 9 {
+		t.Errorf("Snapshot() Max() = %v, want 9 (unaffected by later Add)", snap.Max())
+	}
+}
+
+func TestSafeNumericHistogramEmptyHasInfiniteMinMax(t *testing.T) {
+	h := NewSafeNumericHistogram(20)
+	snap := h.Snapshot()
+
+	if snap.Min() != math.Inf(1) {
+		t.Errorf("Min() of an empty histogram = %v, want +Inf", snap.Min())
+	}
+	if snap.Max() != math.Inf(-1) {
+		t.Errorf("Max() of an empty histogram = %v, want -Inf", snap.Max())
+	}
+}
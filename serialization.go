@@ -0,0 +1,248 @@
+package gohistogram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// histogramBinaryVersion is written as the first byte of every
+// WeightedHistogram binary encoding produced by MarshalBinary, so that
+// UnmarshalBinary can reject encodings produced by an incompatible
+// future format. It also identifies the original NumericHistogram
+// layout (maxbins, total, bins — no min/max/sum), which
+// numericHistogramBinaryVersion has since superseded; UnmarshalBinary
+// still accepts it so old blobs decode rather than misread.
+const histogramBinaryVersion byte = 1
+
+// numericHistogramBinaryVersion is written as the first byte of every
+// NumericHistogram binary encoding since chunk0-6 added Min/Max/Sum,
+// which changed the on-wire layout. It must differ from
+// histogramBinaryVersion so that a pre-chunk0-6 blob is recognized as
+// the older layout instead of being misread as the new one.
+const numericHistogramBinaryVersion byte = 2
+
+type weightedHistogramJSON struct {
+	Bins    []Bin   `json:"bins"`
+	Maxbins int     `json:"maxbins"`
+	Total   float64 `json:"total"`
+	Alpha   float64 `json:"alpha"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h *WeightedHistogram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(weightedHistogramJSON{
+		Bins:    h.Bins,
+		Maxbins: h.Maxbins,
+		Total:   h.Total,
+		Alpha:   h.Alpha,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *WeightedHistogram) UnmarshalJSON(data []byte) error {
+	var aux weightedHistogramJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	h.Bins = aux.Bins
+	h.Maxbins = aux.Maxbins
+	h.Total = aux.Total
+	h.Alpha = aux.Alpha
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing a compact
+// versioned encoding of Maxbins, Alpha, Total and Bins suitable for
+// checkpointing to disk or shipping between services for Merge.
+func (h *WeightedHistogram) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(histogramBinaryVersion)
+
+	fields := []interface{}{int64(h.Maxbins), h.Alpha, h.Total, int64(len(h.Bins))}
+	for _, f := range fields {
+		if err := binary.Write(buf, binary.LittleEndian, f); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, bin := range h.Bins {
+		if err := binary.Write(buf, binary.LittleEndian, bin.Value); err != nil {
+			return nil, err
+		}
+		if err := binary.Write(buf, binary.LittleEndian, bin.Count); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (h *WeightedHistogram) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+
+	version, err := buf.ReadByte()
+	if err != nil {
+		return err
+	}
+	if version != histogramBinaryVersion {
+		return fmt.Errorf("gohistogram: unsupported binary version %d", version)
+	}
+
+	var maxbins, nbins int64
+	if err := binary.Read(buf, binary.LittleEndian, &maxbins); err != nil {
+		return err
+	}
+	if err := binary.Read(buf, binary.LittleEndian, &h.Alpha); err != nil {
+		return err
+	}
+	if err := binary.Read(buf, binary.LittleEndian, &h.Total); err != nil {
+		return err
+	}
+	if err := binary.Read(buf, binary.LittleEndian, &nbins); err != nil {
+		return err
+	}
+
+	h.Maxbins = int(maxbins)
+	h.Bins = make([]Bin, nbins)
+	for i := range h.Bins {
+		if err := binary.Read(buf, binary.LittleEndian, &h.Bins[i].Value); err != nil {
+			return err
+		}
+		if err := binary.Read(buf, binary.LittleEndian, &h.Bins[i].Count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type numericHistogramJSON struct {
+	Bins    []Bin   `json:"bins"`
+	Maxbins int     `json:"maxbins"`
+	Total   uint64  `json:"total"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Sum     float64 `json:"sum"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h *NumericHistogram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(numericHistogramJSON{
+		Bins:    h.bins,
+		Maxbins: h.maxbins,
+		Total:   h.total,
+		Min:     h.min,
+		Max:     h.max,
+		Sum:     h.sum,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *NumericHistogram) UnmarshalJSON(data []byte) error {
+	var aux numericHistogramJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	h.bins = aux.Bins
+	h.maxbins = aux.Maxbins
+	h.total = aux.Total
+	h.min = aux.Min
+	h.max = aux.Max
+	h.sum = aux.Sum
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing a compact
+// versioned encoding of maxbins, total, min, max, sum and bins suitable
+// for checkpointing to disk or shipping between services for Merge.
+func (h *NumericHistogram) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(numericHistogramBinaryVersion)
+
+	fields := []interface{}{int64(h.maxbins), h.total, h.min, h.max, h.sum, int64(len(h.bins))}
+	for _, f := range fields {
+		if err := binary.Write(buf, binary.LittleEndian, f); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, bin := range h.bins {
+		if err := binary.Write(buf, binary.LittleEndian, bin.Value); err != nil {
+			return nil, err
+		}
+		if err := binary.Write(buf, binary.LittleEndian, bin.Count); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It accepts both
+// the current layout (numericHistogramBinaryVersion) and the legacy
+// pre-Min/Max/Sum layout (histogramBinaryVersion), so blobs written by
+// an older version of this package still decode correctly instead of
+// being misread as the new, longer layout.
+func (h *NumericHistogram) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+
+	version, err := buf.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	var maxbins, nbins int64
+	if err := binary.Read(buf, binary.LittleEndian, &maxbins); err != nil {
+		return err
+	}
+
+	switch version {
+	case numericHistogramBinaryVersion:
+		if err := binary.Read(buf, binary.LittleEndian, &h.total); err != nil {
+			return err
+		}
+		if err := binary.Read(buf, binary.LittleEndian, &h.min); err != nil {
+			return err
+		}
+		if err := binary.Read(buf, binary.LittleEndian, &h.max); err != nil {
+			return err
+		}
+		if err := binary.Read(buf, binary.LittleEndian, &h.sum); err != nil {
+			return err
+		}
+	case histogramBinaryVersion:
+		if err := binary.Read(buf, binary.LittleEndian, &h.total); err != nil {
+			return err
+		}
+		h.min = math.Inf(1)
+		h.max = math.Inf(-1)
+		h.sum = 0
+	default:
+		return fmt.Errorf("gohistogram: unsupported binary version %d", version)
+	}
+
+	if err := binary.Read(buf, binary.LittleEndian, &nbins); err != nil {
+		return err
+	}
+
+	h.maxbins = int(maxbins)
+	h.bins = make([]Bin, nbins)
+	for i := range h.bins {
+		if err := binary.Read(buf, binary.LittleEndian, &h.bins[i].Value); err != nil {
+			return err
+		}
+		if err := binary.Read(buf, binary.LittleEndian, &h.bins[i].Count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
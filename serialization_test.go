@@ -0,0 +1,91 @@
+package gohistogram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// legacyNumericHistogramBinary builds a binary blob in the pre-chunk0-6
+// NumericHistogram layout (version 1: maxbins, total, bins — no
+// min/max/sum), as would have been produced before Min/Max/Sum were
+// added to the format.
+func legacyNumericHistogramBinary(t *testing.T, maxbins int, total uint64, bins []Bin) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte(histogramBinaryVersion)
+
+	fields := []interface{}{int64(maxbins), total, int64(len(bins))}
+	for _, f := range fields {
+		if err := binary.Write(buf, binary.LittleEndian, f); err != nil {
+			t.Fatalf("building legacy blob: %v", err)
+		}
+	}
+	for _, bin := range bins {
+		if err := binary.Write(buf, binary.LittleEndian, bin.Value); err != nil {
+			t.Fatalf("building legacy blob: %v", err)
+		}
+		if err := binary.Write(buf, binary.LittleEndian, bin.Count); err != nil {
+			t.Fatalf("building legacy blob: %v", err)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func TestNumericHistogramUnmarshalBinaryLegacyFormat(t *testing.T) {
+	bins := []Bin{{Value: 1, Count: 2}, {Value: 5, Count: 3}}
+	data := legacyNumericHistogramBinary(t, 20, 5, bins)
+
+	h := &NumericHistogram{}
+	if err := h.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary of legacy blob: %v", err)
+	}
+
+	if h.maxbins != 20 {
+		t.Errorf("maxbins = %d, want 20", h.maxbins)
+	}
+	if h.total != 5 {
+		t.Errorf("total = %d, want 5", h.total)
+	}
+	if len(h.bins) != len(bins) || h.bins[0] != bins[0] || h.bins[1] != bins[1] {
+		t.Errorf("bins = %v, want %v", h.bins, bins)
+	}
+	if h.min != math.Inf(1) || h.max != math.Inf(-1) || h.sum != 0 {
+		t.Errorf("min/max/sum = %v/%v/%v, want +Inf/-Inf/0 (legacy blob carries none)", h.min, h.max, h.sum)
+	}
+}
+
+func TestNumericHistogramUnmarshalBinaryUnknownVersion(t *testing.T) {
+	data := legacyNumericHistogramBinary(t, 20, 5, nil)
+	data[0] = 99
+
+	h := &NumericHistogram{}
+	if err := h.UnmarshalBinary(data); err == nil {
+		t.Fatal("UnmarshalBinary with an unknown version byte should return an error, not succeed or panic")
+	}
+}
+
+func TestNumericHistogramMarshalBinaryRoundTripPreservesMinMaxSum(t *testing.T) {
+	h := NewHistogram(20)
+	h.Add(3)
+	h.Add(9)
+	h.Add(1)
+
+	data, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	roundTrip := &NumericHistogram{}
+	if err := roundTrip.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if roundTrip.Min() != h.Min() || roundTrip.Max() != h.Max() || roundTrip.Sum() != h.Sum() {
+		t.Errorf("round trip min/max/sum = %v/%v/%v, want %v/%v/%v",
+			roundTrip.Min(), roundTrip.Max(), roundTrip.Sum(), h.Min(), h.Max(), h.Sum())
+	}
+}
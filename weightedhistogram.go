@@ -74,6 +74,44 @@ func (h *WeightedHistogram) Add(n float64) {
 	h.Bins = append(h.Bins, Bin{Count: 1, Value: n})
 }
 
+// Merge folds other's bins into h, summing the counts of bins with equal
+// values and inserting new bins in sorted order, then re-runs trim() to
+// respect Maxbins. This lets independently maintained histograms (e.g.
+// one per shard or per process) be combined into a single global view
+// without re-inserting raw samples.
+func (h *WeightedHistogram) Merge(other *WeightedHistogram) error {
+	if other == nil {
+		return ErrNilHistogram
+	}
+
+	for _, bin := range other.Bins {
+		h.mergeBin(bin)
+	}
+	h.trim()
+
+	return nil
+}
+
+func (h *WeightedHistogram) mergeBin(bin Bin) {
+	for i := range h.Bins {
+		if h.Bins[i].Value == bin.Value {
+			h.Bins[i].Count += bin.Count
+			return
+		}
+
+		if h.Bins[i].Value > bin.Value {
+			head := append(make([]Bin, 0), h.Bins[0:i]...)
+
+			head = append(head, bin)
+			tail := h.Bins[i:]
+			h.Bins = append(head, tail...)
+			return
+		}
+	}
+
+	h.Bins = append(h.Bins, bin)
+}
+
 func (h *WeightedHistogram) Quantile(q float64) float64 {
 	count := q * h.Total
 	for i := range h.Bins {
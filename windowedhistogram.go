@@ -0,0 +1,93 @@
+package gohistogram
+
+import "fmt"
+
+// A WindowedHistogram maintains a ring of n child NumericHistograms,
+// rotated by a caller-driven call to Rotate (e.g. from a timer). Add
+// writes to the current slot; Quantile, Mean, and CDF transparently
+// query a merged view of every live slot. This gives true time-windowed
+// quantiles (e.g. "p99 latency over the last 5 minutes") without the
+// recency-approximation artifacts of the EWMA in WeightedHistogram.
+type WindowedHistogram struct {
+	slots   []*NumericHistogram
+	maxbins int
+	current int
+}
+
+// NewWindowedHistogram returns a new WindowedHistogram with n slots,
+// each a NumericHistogram with a maximum of maxbins bins. Callers are
+// expected to call Rotate periodically (e.g. once per windowDuration/n)
+// to slide the window forward. n must be at least 1.
+func NewWindowedHistogram(n, maxbins int) (*WindowedHistogram, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("gohistogram: WindowedHistogram needs at least 1 slot, got %d", n)
+	}
+
+	slots := make([]*NumericHistogram, n)
+	for i := range slots {
+		slots[i] = NewHistogram(maxbins)
+	}
+
+	return &WindowedHistogram{
+		slots:   slots,
+		maxbins: maxbins,
+	}, nil
+}
+
+// Add records a new value in the current slot.
+func (h *WindowedHistogram) Add(n float64) {
+	h.slots[h.current].Add(n)
+}
+
+// Rotate advances to the next slot and clears it, so the oldest slot's
+// samples age out of the window as new ones accumulate in its place.
+func (h *WindowedHistogram) Rotate() {
+	h.current = (h.current + 1) % len(h.slots)
+	h.slots[h.current] = NewHistogram(h.maxbins)
+}
+
+// merged returns a single NumericHistogram combining every live slot.
+func (h *WindowedHistogram) merged() *NumericHistogram {
+	merged := NewHistogram(h.maxbins)
+	for _, slot := range h.slots {
+		_ = merged.Merge(slot)
+	}
+
+	return merged
+}
+
+// Quantile returns the value at the given quantile (0..1) across all
+// live slots.
+func (h *WindowedHistogram) Quantile(q float64) float64 {
+	return h.merged().Quantile(q)
+}
+
+// CDF returns the value of the cumulative distribution function at x
+// across all live slots.
+func (h *WindowedHistogram) CDF(x float64) float64 {
+	return h.merged().CDF(x)
+}
+
+// Mean returns the sample mean of the distribution across all live
+// slots.
+func (h *WindowedHistogram) Mean() float64 {
+	return h.merged().Mean()
+}
+
+// Variance returns the variance of the distribution across all live
+// slots.
+func (h *WindowedHistogram) Variance() float64 {
+	return h.merged().Variance()
+}
+
+// Count returns the total number of samples recorded across all live
+// slots.
+func (h *WindowedHistogram) Count() float64 {
+	return h.merged().Count()
+}
+
+// String returns a string reprentation of the windowed histogram, which
+// is useful for printing to a terminal.
+func (h *WindowedHistogram) String() string {
+	return h.merged().String()
+}
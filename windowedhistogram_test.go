@@ -0,0 +1,54 @@
+package gohistogram
+
+import "testing"
+
+func TestNewWindowedHistogramRejectsZeroSlots(t *testing.T) {
+	if _, err := NewWindowedHistogram(0, 20); err == nil {
+		t.Fatal("NewWindowedHistogram(0, ...) should return an error, not a usable histogram")
+	}
+	if _, err := NewWindowedHistogram(-1, 20); err == nil {
+		t.Fatal("NewWindowedHistogram(-1, ...) should return an error, not a usable histogram")
+	}
+}
+
+func TestWindowedHistogramAddAndQuantile(t *testing.T) {
+	h, err := NewWindowedHistogram(3, 20)
+	if err != nil {
+		t.Fatalf("NewWindowedHistogram: %v", err)
+	}
+
+	for i := 1; i <= 100; i++ {
+		h.Add(float64(i))
+	}
+
+	if got := h.Count(); got != 100 {
+		t.Errorf("Count() = %v, want 100", got)
+	}
+	if got := h.Quantile(0.5); got < 40 || got > 60 {
+		t.Errorf("Quantile(0.5) = %v, want roughly 50", got)
+	}
+}
+
+func TestWindowedHistogramRotateAgesOutOldestSlot(t *testing.T) {
+	h, err := NewWindowedHistogram(2, 20)
+	if err != nil {
+		t.Fatalf("NewWindowedHistogram: %v", err)
+	}
+
+	h.Add(1)
+	h.Rotate()
+	h.Add(2)
+
+	if got := h.Count(); got != 2 {
+		t.Fatalf("Count() after one rotation = %v, want 2 (both slots still live)", got)
+	}
+
+	h.Rotate() // wraps back around to the slot holding the first Add, clearing it
+
+	if got := h.Count(); got != 1 {
+		t.Errorf("Count() after the first slot ages out = %v, want 1", got)
+	}
+	if got := h.Quantile(1); got != 2 {
+		t.Errorf("Quantile(1) after aging out = %v, want 2 (the surviving value)", got)
+	}
+}